@@ -0,0 +1,23 @@
+package nifi
+
+import "testing"
+
+func TestNewClient_BearerTokenProviderUsesConfiguredTransport(t *testing.T) {
+	client, err := NewClient(Config{
+		Host:     "nifi.example.com",
+		ApiPath:  "nifi-api",
+		Username: "admin",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	provider, ok := client.auth.(*bearerTokenProvider)
+	if !ok {
+		t.Fatalf("expected *bearerTokenProvider, got %T", client.auth)
+	}
+	if provider.httpClient != client.Client {
+		t.Fatal("bearerTokenProvider.httpClient was not wired to the client's *http.Client, so access/token calls would bypass configured TLS settings")
+	}
+}
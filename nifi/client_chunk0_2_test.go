@@ -0,0 +1,114 @@
+package nifi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestJsonCall_RetriesRetryableStatusesThenSucceeds(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"revision":{"version":1}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Host:    server.Listener.Addr().String(),
+		ApiPath: "nifi-api",
+		Retry:   RetryConfig{MaxAttempts: 5, BaseDelay: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	var out ProcessGroup
+	err, code := client.JsonCall(context.Background(), "GET", client.buildUrl("/process-groups/root"), nil, &out)
+	if err != nil {
+		t.Fatalf("JsonCall: %s", err)
+	}
+	if code != http.StatusOK {
+		t.Fatalf("expected final status 200, got %d", code)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts (two 503s then a 200), got %d", attempts)
+	}
+}
+
+func TestJsonCall_GivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Host:    server.Listener.Addr().String(),
+		ApiPath: "nifi-api",
+		Retry:   RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	err, code := client.JsonCall(context.Background(), "GET", client.buildUrl("/process-groups/root"), nil, nil)
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("expected final status 503, got %d", code)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected exactly MaxAttempts (3) attempts, got %d", attempts)
+	}
+}
+
+func TestJsonCall_InvalidatesAuthOn401(t *testing.T) {
+	tokenCalls := 0
+	unauthorized := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nifi-api/access/token":
+			tokenCalls++
+			w.Write([]byte("token"))
+		default:
+			if unauthorized {
+				unauthorized = false
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"revision":{"version":1}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Host:     server.Listener.Addr().String(),
+		ApiPath:  "nifi-api",
+		Username: "admin",
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	var out ProcessGroup
+	err, code := client.JsonCall(context.Background(), "GET", client.buildUrl("/process-groups/root"), nil, &out)
+	if err != nil {
+		t.Fatalf("JsonCall: %s", err)
+	}
+	if code != http.StatusOK {
+		t.Fatalf("expected final status 200, got %d", code)
+	}
+	if tokenCalls != 2 {
+		t.Fatalf("expected the token to be fetched twice (once, then again after 401 invalidated it), got %d", tokenCalls)
+	}
+}
@@ -0,0 +1,129 @@
+package nifi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateParameterContext_PollsUntilCompleteThenDeletesAndRefreshes(t *testing.T) {
+	var polls int
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests":
+			w.Write([]byte(`{"request":{"requestId":"req-1","uri":"http://` + r.Host + `/nifi-api/parameter-contexts/pc-1/update-requests/req-1","complete":false}}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests/req-1":
+			polls++
+			if polls < 2 {
+				w.Write([]byte(`{"request":{"requestId":"req-1","complete":false,"percentCompleted":50}}`))
+				return
+			}
+			w.Write([]byte(`{"request":{"requestId":"req-1","complete":true,"percentCompleted":100}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests/req-1":
+			deleted = true
+			w.Write([]byte(`{}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1":
+			w.Write([]byte(`{"revision":{"version":2},"component":{"id":"pc-1","name":"ctx"}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	parameterContext := &ParameterContext{
+		Revision:  Revision{Version: 1},
+		Component: ParameterContextComponent{Id: "pc-1", Name: "ctx"},
+	}
+	if err := client.UpdateParameterContext(context.Background(), parameterContext); err != nil {
+		t.Fatalf("UpdateParameterContext: %s", err)
+	}
+	if polls < 2 {
+		t.Fatalf("expected at least 2 polls before completion, got %d", polls)
+	}
+	if !deleted {
+		t.Fatal("expected the update request resource to be deleted after completion")
+	}
+	if parameterContext.Revision.Version != 2 {
+		t.Fatalf("expected parameterContext to be refreshed with the post-update revision, got version %d", parameterContext.Revision.Version)
+	}
+}
+
+func TestUpdateParameterContext_PropagatesFailureReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests":
+			w.Write([]byte(`{"request":{"requestId":"req-1","uri":"http://` + r.Host + `/nifi-api/parameter-contexts/pc-1/update-requests/req-1","complete":false}}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests/req-1":
+			w.Write([]byte(`{"request":{"requestId":"req-1","complete":false,"failureReason":"referencing component is invalid"}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	parameterContext := &ParameterContext{
+		Revision:  Revision{Version: 1},
+		Component: ParameterContextComponent{Id: "pc-1", Name: "ctx"},
+	}
+	if err := client.UpdateParameterContext(context.Background(), parameterContext); err == nil {
+		t.Fatal("expected an error when the async request reports a failure reason")
+	}
+}
+
+func TestUpdateParameterContext_RefreshesRevisionOnConflictThenRetries(t *testing.T) {
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests":
+			posts++
+			var body ParameterContext
+			json.NewDecoder(r.Body).Decode(&body)
+			if posts == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			if body.Revision.Version != 5 {
+				t.Fatalf("expected retry to use the refreshed revision 5, got %d", body.Revision.Version)
+			}
+			w.Write([]byte(`{"request":{"requestId":"req-1","uri":"http://` + r.Host + `/nifi-api/parameter-contexts/pc-1/update-requests/req-1","complete":true}}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests/req-1":
+			w.Write([]byte(`{"request":{"requestId":"req-1","complete":true}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1/update-requests/req-1":
+			w.Write([]byte(`{}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/parameter-contexts/pc-1":
+			w.Write([]byte(`{"revision":{"version":5},"component":{"id":"pc-1","name":"ctx"}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	parameterContext := &ParameterContext{
+		Revision:  Revision{Version: 1},
+		Component: ParameterContextComponent{Id: "pc-1", Name: "ctx"},
+	}
+	if err := client.UpdateParameterContext(context.Background(), parameterContext); err != nil {
+		t.Fatalf("UpdateParameterContext: %s", err)
+	}
+	if posts != 2 {
+		t.Fatalf("expected exactly one conflict retry (2 POSTs total), got %d", posts)
+	}
+}
@@ -0,0 +1,139 @@
+package nifi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSaveVersion_PollsVersionRequestThenDeletesIt(t *testing.T) {
+	var polls int
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/process-groups/pg-1":
+			w.Write([]byte(`{"revision":{"version":3},"component":{"id":"pg-1","versionControlInformation":{"registryId":"reg-1","bucketId":"bucket-1","flowId":"flow-1","version":1}}}`))
+		case r.Method == "POST" && r.URL.Path == "/nifi-api/versions/update-requests/process-groups/pg-1":
+			w.Write([]byte(`{"request":{"requestId":"req-1","uri":"http://` + r.Host + `/nifi-api/versions/update-requests/pg-1/req-1","complete":false}}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/versions/update-requests/pg-1/req-1":
+			polls++
+			if polls < 2 {
+				w.Write([]byte(`{"request":{"requestId":"req-1","complete":false}}`))
+				return
+			}
+			w.Write([]byte(`{"request":{"requestId":"req-1","complete":true}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/nifi-api/versions/update-requests/pg-1/req-1":
+			deleted = true
+			w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	if err := client.SaveVersion(context.Background(), "pg-1", "a comment"); err != nil {
+		t.Fatalf("SaveVersion: %s", err)
+	}
+	if polls < 2 {
+		t.Fatalf("expected at least 2 polls before completion, got %d", polls)
+	}
+	if !deleted {
+		t.Fatal("expected the version update-request resource to be deleted after completion")
+	}
+}
+
+func TestSaveVersion_ErrorsWhenNotUnderVersionControl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"revision":{"version":3},"component":{"id":"pg-1"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	if err := client.SaveVersion(context.Background(), "pg-1", "a comment"); err == nil {
+		t.Fatal("expected an error when the process group has no versionControlInformation")
+	}
+}
+
+func TestChangeVersion_SendsTargetVersionAndPolls(t *testing.T) {
+	var sentVersion int
+	var polled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/process-groups/pg-1":
+			w.Write([]byte(`{"revision":{"version":3},"component":{"id":"pg-1","versionControlInformation":{"registryId":"reg-1","bucketId":"bucket-1","flowId":"flow-1","version":1}}}`))
+		case r.Method == "POST" && r.URL.Path == "/nifi-api/versions/update-requests/process-groups/pg-1":
+			var body versionUpdateRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.VersionControlInformation != nil {
+				sentVersion = body.VersionControlInformation.Version
+			}
+			w.Write([]byte(`{"request":{"requestId":"req-1","uri":"http://` + r.Host + `/nifi-api/versions/update-requests/pg-1/req-1","complete":true}}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/versions/update-requests/pg-1/req-1":
+			polled = true
+			w.Write([]byte(`{"request":{"requestId":"req-1","complete":true}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/nifi-api/versions/update-requests/pg-1/req-1":
+			w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	if err := client.ChangeVersion(context.Background(), "pg-1", 7); err != nil {
+		t.Fatalf("ChangeVersion: %s", err)
+	}
+	if sentVersion != 7 {
+		t.Fatalf("expected the update request to carry target version 7, got %d", sentVersion)
+	}
+	if !polled {
+		t.Fatal("expected the version update-request to be polled")
+	}
+}
+
+func TestRevertLocalChanges_UsesRevertRequestsEndpointAndPolls(t *testing.T) {
+	var polled bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/process-groups/pg-1":
+			w.Write([]byte(`{"revision":{"version":3},"component":{"id":"pg-1","versionControlInformation":{"registryId":"reg-1","bucketId":"bucket-1","flowId":"flow-1","version":1}}}`))
+		case r.Method == "POST" && r.URL.Path == "/nifi-api/versions/revert-requests/process-groups/pg-1":
+			w.Write([]byte(`{"request":{"requestId":"req-1","uri":"http://` + r.Host + `/nifi-api/versions/revert-requests/pg-1/req-1","complete":false}}`))
+		case r.Method == "GET" && r.URL.Path == "/nifi-api/versions/revert-requests/pg-1/req-1":
+			polled = true
+			w.Write([]byte(`{"request":{"requestId":"req-1","complete":true}}`))
+		case r.Method == "DELETE" && r.URL.Path == "/nifi-api/versions/revert-requests/pg-1/req-1":
+			w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	if err := client.RevertLocalChanges(context.Background(), "pg-1"); err != nil {
+		t.Fatalf("RevertLocalChanges: %s", err)
+	}
+	if !polled {
+		t.Fatal("expected the revert request to be polled")
+	}
+}
@@ -0,0 +1,137 @@
+package nifi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetRemoteProcessGroupTransmitting_SendsTransmittingFieldWhenStopping(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %s", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	rpg := &RemoteProcessGroup{
+		Revision:  Revision{Version: 1},
+		Component: RemoteProcessGroupComponent{Id: "rpg-1", Transmitting: true},
+	}
+	if err := client.StopTransmitting(context.Background(), rpg); err != nil {
+		t.Fatalf("StopTransmitting: %s", err)
+	}
+
+	component, ok := body["component"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a component object in the request body, got %#v", body)
+	}
+	transmitting, present := component["transmitting"]
+	if !present {
+		t.Fatal("request body dropped the transmitting field entirely when false, so the server would never learn to stop transmitting")
+	}
+	if transmitting != false {
+		t.Fatalf("expected transmitting=false, got %#v", transmitting)
+	}
+	if rpg.Component.Transmitting {
+		t.Fatal("expected the local RemoteProcessGroup to reflect Transmitting=false after StopTransmitting succeeds")
+	}
+}
+
+func TestSetRemoteProcessGroupTransmitting_RefreshesRevisionOnConflict(t *testing.T) {
+	var puts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT":
+			puts++
+			var body RemoteProcessGroup
+			json.NewDecoder(r.Body).Decode(&body)
+			if puts == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			if body.Revision.Version != 9 {
+				t.Fatalf("expected retry to use refreshed revision 9, got %d", body.Revision.Version)
+			}
+			w.Write([]byte(`{}`))
+		case r.Method == "GET":
+			w.Write([]byte(`{"revision":{"version":9},"component":{"id":"rpg-1"}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	rpg := &RemoteProcessGroup{Revision: Revision{Version: 1}, Component: RemoteProcessGroupComponent{Id: "rpg-1"}}
+	if err := client.StartTransmitting(context.Background(), rpg); err != nil {
+		t.Fatalf("StartTransmitting: %s", err)
+	}
+	if puts != 2 {
+		t.Fatalf("expected exactly one conflict retry (2 PUTs total), got %d", puts)
+	}
+}
+
+func TestSetInputPortState_SendsStateAndUpdatesLocalCopyOnSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/nifi-api/input-ports/port-1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body Port
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Component.State != "RUNNING" {
+			t.Fatalf("expected state RUNNING in request body, got %q", body.Component.State)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	port := &Port{Revision: Revision{Version: 1}, Component: PortComponent{Id: "port-1"}}
+	if err := client.StartInputPort(context.Background(), port); err != nil {
+		t.Fatalf("StartInputPort: %s", err)
+	}
+	if port.Component.State != "RUNNING" {
+		t.Fatalf("expected the local port state to be updated to RUNNING after success, got %q", port.Component.State)
+	}
+}
+
+func TestSetRemoteProcessGroupOutputPortTransmitting_UpdatesFromResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/nifi-api/remote-process-groups/rpg-1/output-ports/port-1/run-status" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"revision":{"version":4},"id":"port-1","groupId":"rpg-1","transmitting":false}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	portState := &RemoteProcessGroupPortState{Revision: Revision{Version: 1}, Id: "port-1", GroupId: "rpg-1", Transmitting: true}
+	if err := client.SetRemoteProcessGroupOutputPortTransmitting(context.Background(), portState, false); err != nil {
+		t.Fatalf("SetRemoteProcessGroupOutputPortTransmitting: %s", err)
+	}
+	if portState.Revision.Version != 4 {
+		t.Fatalf("expected portState to be refreshed from the response, got version %d", portState.Revision.Version)
+	}
+}
@@ -0,0 +1,128 @@
+package nifi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateFunnel_DelegatesToGeneratedClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/nifi-api/process-groups/pg-1/funnels" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"revision":{"version":1},"component":{"id":"funnel-1","parentGroupId":"pg-1","position":{"x":1,"y":2}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	funnel := &Funnel{Component: FunnelComponent{ParentGroupId: "pg-1"}}
+	if err := client.CreateFunnel(context.Background(), funnel); err != nil {
+		t.Fatalf("CreateFunnel: %s", err)
+	}
+	if funnel.Component.Id != "funnel-1" || funnel.Revision.Version != 1 {
+		t.Fatalf("expected the funnel to be populated from the generated response, got %+v", funnel)
+	}
+}
+
+func TestGetFunnel_ReturnsNilOnNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	funnel, err := client.GetFunnel(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("GetFunnel: %s", err)
+	}
+	if funnel != nil {
+		t.Fatalf("expected a nil funnel for a 404, got %+v", funnel)
+	}
+}
+
+func TestUpdateFunnel_RefreshesRevisionOnConflict(t *testing.T) {
+	var puts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			puts++
+			if puts == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.Write([]byte(`{"revision":{"version":6},"component":{"id":"funnel-1","parentGroupId":"pg-1"}}`))
+		case "GET":
+			w.Write([]byte(`{"revision":{"version":6},"component":{"id":"funnel-1","parentGroupId":"pg-1"}}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	funnel := &Funnel{Revision: Revision{Version: 1}, Component: FunnelComponent{Id: "funnel-1"}}
+	if err := client.UpdateFunnel(context.Background(), funnel); err != nil {
+		t.Fatalf("UpdateFunnel: %s", err)
+	}
+	if puts != 2 {
+		t.Fatalf("expected exactly one conflict retry (2 PUTs total), got %d", puts)
+	}
+	if funnel.Revision.Version != 6 {
+		t.Fatalf("expected the funnel to be refreshed with the post-retry revision, got %d", funnel.Revision.Version)
+	}
+}
+
+func TestDeleteFunnel_DelegatesToGeneratedClient(t *testing.T) {
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/nifi-api/funnels/funnel-1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		deleted = true
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	if err := client.DeleteFunnel(context.Background(), "funnel-1"); err != nil {
+		t.Fatalf("DeleteFunnel: %s", err)
+	}
+	if !deleted {
+		t.Fatal("expected the generated client to issue the DELETE request")
+	}
+}
+
+func TestDeleteFunnel_ReturnsErrorOnFailureStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.Listener.Addr().String(), ApiPath: "nifi-api"})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	if err := client.DeleteFunnel(context.Background(), "funnel-1"); err == nil {
+		t.Fatal("expected an error when the generated client reports a failure status")
+	}
+}
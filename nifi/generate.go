@@ -0,0 +1,15 @@
+package nifi
+
+// The client in this package is hand-maintained and only covers a subset of
+// NiFi's REST API. NiFi publishes a Swagger/OpenAPI document describing the
+// full API; generating a client from it would close that gap and keep
+// request/response types in sync across NiFi versions.
+//
+// nifi/generated currently holds a hand-written stand-in for that generated
+// client, covering only the Funnel endpoints against a small, hand-authored
+// spec excerpt — see nifi/generated/doc.go for the current status. Running
+// the directive below against oapi-codegen (not yet done in this checkout)
+// would overwrite that stand-in with real generated output and drop its
+// ClientWithResponses/parse helpers; reconcile nifi/generated/doc.go with
+// the real output before relying on it.
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --config generated/oapi-codegen-config.yaml generated/nifi-openapi-1.23.2.yaml